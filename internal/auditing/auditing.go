@@ -0,0 +1,56 @@
+// Package auditing records a structured trail of every Check, Write,
+// Expand, Lookup, and Watch call handled by the gRPC server, so that
+// operators can answer "who could see what, and when" after the fact.
+package auditing
+
+import (
+	"context"
+	"time"
+)
+
+// AuditRequestContext describes a single audited gRPC call (or, for
+// streaming Watch responses, a single emitted message).
+type AuditRequestContext struct {
+	// Tenant is the caller's tenant, if the request was tenant-scoped.
+	Tenant string
+
+	// Subject identifies the caller, typically a service account or
+	// end-user identifier extracted from the request credentials.
+	Subject string
+
+	// Method is the fully-qualified gRPC method, e.g.
+	// "/authzed.api.v1.PermissionsService/Check".
+	Method string
+
+	// RequestJSON and ResponseJSON are the JSON-marshalled protobuf
+	// request and response, size-capped to avoid unbounded audit rows.
+	RequestJSON  []byte
+	ResponseJSON []byte
+
+	// StatusCode is the gRPC status code name, e.g. "OK" or "PermissionDenied".
+	StatusCode string
+
+	// ErrorString is the error's message, if the call did not succeed.
+	// Error values are never persisted, only their string representation.
+	ErrorString string
+
+	// Latency is the time spent serving the call (or, for a Watch
+	// message, the time since the previous message on the stream).
+	Latency time.Duration
+
+	// PreZookie and PostZookie are the zookie tokens observed before and
+	// after the call, when applicable (e.g. Check's AtRevision and the
+	// zookie embedded in a Write's response).
+	PreZookie  string
+	PostZookie string
+
+	// Timestamp is when the call (or message) was recorded.
+	Timestamp time.Time
+}
+
+// Auditing indexes audit records for later querying. Implementations must
+// be safe for concurrent use, since the interceptors call Index from every
+// in-flight RPC's goroutine.
+type Auditing interface {
+	Index(ctx context.Context, entry AuditRequestContext) error
+}
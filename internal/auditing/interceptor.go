@@ -0,0 +1,181 @@
+package auditing
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// maxAuditPayloadBytes caps how much of a request/response protobuf is
+// persisted per audit entry, so a pathologically large Write doesn't blow
+// out the audit table.
+const maxAuditPayloadBytes = 16 * 1024
+
+// subjectMetadataKey is the gRPC metadata header used to identify the
+// calling subject for audit purposes.
+const subjectMetadataKey = "spicedb-subject"
+
+// UnaryServerInterceptor returns a grpc.UnaryServerInterceptor that indexes
+// every unary call (Check, Write, Expand, Lookup) into the given Auditing
+// backend.
+func UnaryServerInterceptor(auditing Auditing) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+
+		entry := AuditRequestContext{
+			Tenant:      tenantFromMetadata(ctx),
+			Subject:     subjectFromMetadata(ctx),
+			Method:      info.FullMethod,
+			RequestJSON: marshalCapped(req),
+			StatusCode:  status.Code(err).String(),
+			Latency:     time.Since(start),
+			Timestamp:   start,
+		}
+		if err != nil {
+			entry.ErrorString = err.Error()
+		} else {
+			entry.ResponseJSON = marshalCapped(resp)
+		}
+
+		if indexErr := auditing.Index(ctx, entry); indexErr != nil {
+			// Auditing failures must never fail the underlying RPC.
+			_ = indexErr
+		}
+
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor returns a grpc.StreamServerInterceptor that
+// indexes the overall stream call, and wraps the stream so that every
+// message sent on it (e.g. each WatchResponse) is individually auditable
+// via an per-message hook.
+func StreamServerInterceptor(auditing Auditing) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		ctx := ss.Context()
+
+		wrapped := &auditingServerStream{
+			ServerStream: ss,
+			auditing:     auditing,
+			ctx:          ctx,
+			method:       info.FullMethod,
+			tenant:       tenantFromMetadata(ctx),
+			subject:      subjectFromMetadata(ctx),
+		}
+
+		err := handler(srv, wrapped)
+
+		entry := AuditRequestContext{
+			Tenant:     wrapped.tenant,
+			Subject:    wrapped.subject,
+			Method:     info.FullMethod,
+			StatusCode: status.Code(err).String(),
+			Latency:    time.Since(start),
+			Timestamp:  start,
+		}
+		if err != nil {
+			entry.ErrorString = err.Error()
+		}
+
+		if indexErr := auditing.Index(ctx, entry); indexErr != nil {
+			_ = indexErr
+		}
+
+		return err
+	}
+}
+
+// auditingServerStream wraps a grpc.ServerStream so that every message sent
+// on it (e.g. each WatchResponse emitted by watchServer.Watch) is audited
+// individually, in addition to the overall-call entry recorded once the
+// stream completes.
+type auditingServerStream struct {
+	grpc.ServerStream
+
+	auditing Auditing
+	ctx      context.Context
+	method   string
+	tenant   string
+	subject  string
+
+	lastSend time.Time
+}
+
+func (s *auditingServerStream) SendMsg(m any) error {
+	sendStart := time.Now()
+	err := s.ServerStream.SendMsg(m)
+
+	since := sendStart
+	if !s.lastSend.IsZero() {
+		since = s.lastSend
+	}
+	s.lastSend = sendStart
+
+	entry := AuditRequestContext{
+		Tenant:     s.tenant,
+		Subject:    s.subject,
+		Method:     s.method,
+		StatusCode: status.Code(err).String(),
+		Latency:    sendStart.Sub(since),
+		Timestamp:  sendStart,
+	}
+	if err != nil {
+		entry.ErrorString = err.Error()
+	} else {
+		entry.ResponseJSON = marshalCapped(m)
+	}
+
+	if indexErr := s.auditing.Index(s.ctx, entry); indexErr != nil {
+		_ = indexErr
+	}
+
+	return err
+}
+
+func tenantFromMetadata(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get("spicedb-tenant")
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func subjectFromMetadata(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get(subjectMetadataKey)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func marshalCapped(msg any) []byte {
+	pb, ok := msg.(proto.Message)
+	if !ok {
+		return nil
+	}
+
+	marshalled, err := protojson.Marshal(pb)
+	if err != nil {
+		return nil
+	}
+
+	if len(marshalled) > maxAuditPayloadBytes {
+		return marshalled[:maxAuditPayloadBytes]
+	}
+	return marshalled
+}
@@ -0,0 +1,35 @@
+package auditing
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryAuditing is an in-memory Auditing backend intended for tests: it
+// simply retains every entry it is given.
+type MemoryAuditing struct {
+	mu      sync.Mutex
+	entries []AuditRequestContext
+}
+
+// NewMemoryAuditing creates an Auditing backend that keeps every indexed
+// entry in memory for later inspection by a test.
+func NewMemoryAuditing() *MemoryAuditing {
+	return &MemoryAuditing{}
+}
+
+func (m *MemoryAuditing) Index(_ context.Context, entry AuditRequestContext) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.entries = append(m.entries, entry)
+	return nil
+}
+
+// Entries returns a copy of every entry indexed so far.
+func (m *MemoryAuditing) Entries() []AuditRequestContext {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]AuditRequestContext(nil), m.entries...)
+}
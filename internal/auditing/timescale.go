@@ -0,0 +1,209 @@
+package auditing
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const createAuditEventsTable = `
+CREATE TABLE IF NOT EXISTS spicedb_audit_events (
+	tenant        VARCHAR NOT NULL DEFAULT '',
+	subject       VARCHAR NOT NULL,
+	method        VARCHAR NOT NULL,
+	request_json  JSONB NOT NULL,
+	response_json JSONB NOT NULL,
+	status_code   VARCHAR NOT NULL,
+	error_string  VARCHAR NOT NULL DEFAULT '',
+	latency_ms    BIGINT NOT NULL,
+	pre_zookie    VARCHAR NOT NULL DEFAULT '',
+	post_zookie   VARCHAR NOT NULL DEFAULT '',
+	timestamp     TIMESTAMPTZ NOT NULL
+);`
+
+const createHypertable = `SELECT create_hypertable('spicedb_audit_events', 'timestamp', if_not_exists => true);`
+
+const createTenantMethodIndex = `CREATE INDEX IF NOT EXISTS ix_spicedb_audit_events_tenant_method_timestamp
+	ON spicedb_audit_events (tenant, method, timestamp DESC);`
+
+const createSubjectIndex = `CREATE INDEX IF NOT EXISTS ix_spicedb_audit_events_subject_timestamp
+	ON spicedb_audit_events (subject, timestamp DESC);`
+
+// TimescaleConfig configures the TimescaleDB Auditing backend.
+type TimescaleConfig struct {
+	// FlushInterval is how often buffered entries are flushed to the
+	// database in the background. Defaults to one second.
+	FlushInterval time.Duration
+
+	// MaxBatchSize is the largest number of entries flushed in a single
+	// batched insert. Defaults to 500.
+	MaxBatchSize int
+}
+
+func (c TimescaleConfig) withDefaults() TimescaleConfig {
+	if c.FlushInterval <= 0 {
+		c.FlushInterval = time.Second
+	}
+	if c.MaxBatchSize <= 0 {
+		c.MaxBatchSize = 500
+	}
+	return c
+}
+
+// TimescaleAuditing is the production Auditing backend: it buffers entries
+// in memory and flushes them to a TimescaleDB hypertable in the background,
+// so that Index never blocks the calling RPC on a database round-trip.
+type TimescaleAuditing struct {
+	pool   *pgxpool.Pool
+	config TimescaleConfig
+
+	mu      sync.Mutex
+	pending []AuditRequestContext
+
+	closeOnce sync.Once
+	done      chan struct{}
+	stopped   chan struct{}
+}
+
+// NewTimescaleAuditing connects to dsn, ensures the spicedb_audit_events
+// hypertable and its indexes exist, and starts the background flusher.
+func NewTimescaleAuditing(ctx context.Context, dsn string, config TimescaleConfig) (*TimescaleAuditing, error) {
+	pool, err := pgxpool.New(ctx, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to timescale auditing backend: %w", err)
+	}
+
+	for _, stmt := range []string{createAuditEventsTable, createHypertable, createTenantMethodIndex, createSubjectIndex} {
+		if _, err := pool.Exec(ctx, stmt); err != nil {
+			pool.Close()
+			return nil, fmt.Errorf("failed to initialize timescale auditing schema: %w", err)
+		}
+	}
+
+	a := &TimescaleAuditing{
+		pool:    pool,
+		config:  config.withDefaults(),
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	go a.runFlusher()
+
+	return a, nil
+}
+
+func (a *TimescaleAuditing) Index(_ context.Context, entry AuditRequestContext) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.pending = append(a.pending, entry)
+	return nil
+}
+
+func (a *TimescaleAuditing) runFlusher() {
+	defer close(a.stopped)
+
+	ticker := time.NewTicker(a.config.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := a.flush(context.Background()); err != nil {
+				slog.Error("failed to flush audit events to timescale", "error", err)
+			}
+		case <-a.done:
+			// flush takes at most MaxBatchSize entries per call, so keep
+			// flushing until nothing is left buffered.
+			for {
+				a.mu.Lock()
+				remaining := len(a.pending)
+				a.mu.Unlock()
+				if remaining == 0 {
+					return
+				}
+				if err := a.flush(context.Background()); err != nil {
+					slog.Error("failed to flush audit events to timescale on shutdown", "error", err)
+					return
+				}
+			}
+		}
+	}
+}
+
+func (a *TimescaleAuditing) flush(ctx context.Context) error {
+	a.mu.Lock()
+	if len(a.pending) == 0 {
+		a.mu.Unlock()
+		return nil
+	}
+
+	batch := a.pending
+	if len(batch) > a.config.MaxBatchSize {
+		batch, a.pending = batch[:a.config.MaxBatchSize], batch[a.config.MaxBatchSize:]
+	} else {
+		a.pending = nil
+	}
+	a.mu.Unlock()
+
+	rows := make([][]any, 0, len(batch))
+	for _, entry := range batch {
+		rows = append(rows, []any{
+			entry.Tenant,
+			entry.Subject,
+			entry.Method,
+			nonNullJSON(entry.RequestJSON),
+			nonNullJSON(entry.ResponseJSON),
+			entry.StatusCode,
+			entry.ErrorString,
+			entry.Latency.Milliseconds(),
+			entry.PreZookie,
+			entry.PostZookie,
+			entry.Timestamp,
+		})
+	}
+
+	_, err := a.pool.CopyFrom(
+		ctx,
+		pgx.Identifier{"spicedb_audit_events"},
+		[]string{
+			"tenant", "subject", "method", "request_json", "response_json",
+			"status_code", "error_string", "latency_ms", "pre_zookie", "post_zookie", "timestamp",
+		},
+		pgx.CopyFromRows(rows),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to copy audit events into timescale: %w", err)
+	}
+
+	return nil
+}
+
+// nonNullJSON substitutes the JSON literal null for a nil payload.
+// RequestJSON/ResponseJSON are nil whenever marshalCapped couldn't
+// produce a payload (most commonly ResponseJSON on an RPC that returned
+// an error, the common case for an audit-worthy denial); request_json and
+// response_json are NOT NULL, so inserting a Go nil there would fail the
+// whole batched CopyFrom and silently drop every entry batched alongside
+// it, including ones that succeeded.
+func nonNullJSON(payload []byte) []byte {
+	if payload == nil {
+		return []byte("null")
+	}
+	return payload
+}
+
+// Close stops the background flusher, flushing any remaining buffered
+// entries first, and closes the connection pool.
+func (a *TimescaleAuditing) Close() error {
+	a.closeOnce.Do(func() {
+		close(a.done)
+		<-a.stopped
+		a.pool.Close()
+	})
+	return nil
+}
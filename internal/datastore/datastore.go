@@ -0,0 +1,52 @@
+// Package datastore defines the storage-engine-agnostic interface that
+// every backend (postgres, memdb, ...) implements, along with the errors
+// and revision types shared across them.
+package datastore
+
+import (
+	"context"
+
+	"github.com/shopspring/decimal"
+
+	v0 "github.com/authzed/spicedb/pkg/proto/authzed/api/v0"
+)
+
+// Ellipsis is the special relation name meaning "this object itself",
+// used when checking that a namespace exists without checking a specific
+// relation on it.
+const Ellipsis = "..."
+
+// RevisionChanges is a single batch of tuple changes committed together at
+// Revision.
+type RevisionChanges struct {
+	Revision decimal.Decimal
+	Changes  []*v0.RelationTupleUpdate
+}
+
+// ErrWatchCanceled is returned on the Watch error channel when the
+// subscriber's own context was canceled.
+type ErrWatchCanceled struct{}
+
+func (ErrWatchCanceled) Error() string { return "watch was canceled by the caller" }
+
+// ErrWatchDisconnected is returned on the Watch error channel when the
+// requested start revision has fallen behind the changelog's GC horizon,
+// so the subscriber cannot be caught up from the changelog alone.
+type ErrWatchDisconnected struct{}
+
+func (ErrWatchDisconnected) Error() string {
+	return "watch fell too far behind and was disconnected"
+}
+
+// Datastore is the interface implemented by every supported storage
+// backend.
+type Datastore interface {
+	// Revision returns the most recent revision visible to new reads.
+	Revision(ctx context.Context) (decimal.Decimal, error)
+
+	// Watch streams every RevisionChanges committed after afterRevision.
+	// When tenantIDs is non-empty, only changes to tuples carrying one of
+	// those tenant IDs are sent; a nil or empty tenantIDs means "no tenant
+	// scoping", matching the pre-tenant-aware behavior.
+	Watch(ctx context.Context, afterRevision decimal.Decimal, tenantIDs []string) (<-chan RevisionChanges, <-chan error)
+}
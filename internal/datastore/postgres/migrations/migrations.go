@@ -0,0 +1,71 @@
+// Package migrations holds the numbered goose migrations for the postgres
+// datastore and a Provider that applies them. Unlike the previous
+// init()-based registry, nothing here runs automatically on import: a
+// caller must construct a Provider and explicitly invoke Up/Down/Status, so
+// that linking this package no longer implies running every migration it
+// contains.
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+
+	"github.com/pressly/goose/v3"
+)
+
+//go:embed *.sql
+var migrationFiles embed.FS
+
+// Provider applies the embedded postgres migrations against a database/sql
+// connection using goose.
+type Provider struct {
+	provider *goose.Provider
+}
+
+// NewProvider constructs a migration Provider bound to db.
+func NewProvider(db *sql.DB) (*Provider, error) {
+	provider, err := goose.NewProvider(goose.DialectPostgres, db, migrationFiles)
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct migration provider: %w", err)
+	}
+
+	return &Provider{provider: provider}, nil
+}
+
+// Up applies every migration that has not yet been run.
+func (p *Provider) Up(ctx context.Context) error {
+	_, err := p.provider.Up(ctx)
+	return err
+}
+
+// Down rolls back the most recently applied migration.
+func (p *Provider) Down(ctx context.Context) error {
+	_, err := p.provider.Down(ctx)
+	return err
+}
+
+// DownToZero rolls back every applied migration, in reverse order, down to
+// an empty schema.
+func (p *Provider) DownToZero(ctx context.Context) error {
+	_, err := p.provider.DownTo(ctx, 0)
+	return err
+}
+
+// Redo rolls back and then re-applies the most recently applied migration.
+func (p *Provider) Redo(ctx context.Context) error {
+	if _, err := p.provider.Down(ctx); err != nil {
+		return fmt.Errorf("failed to roll back current migration: %w", err)
+	}
+	if _, err := p.provider.UpByOne(ctx); err != nil {
+		return fmt.Errorf("failed to re-apply current migration: %w", err)
+	}
+	return nil
+}
+
+// Status reports, for every embedded migration, whether it has been
+// applied.
+func (p *Provider) Status(ctx context.Context) ([]*goose.MigrationStatus, error) {
+	return p.provider.Status(ctx)
+}
@@ -0,0 +1,111 @@
+package graph
+
+import v1 "github.com/authzed/spicedb/pkg/proto/dispatch/v1"
+
+// ExcludedSubjectSet tracks, per resource ID, the subject IDs that a
+// wildcard grant for that resource has had subtracted from it by an
+// exclusion (`-`) branch somewhere beneath it in the permission
+// expression. It is combined across union (`+`) and intersection (`&`)
+// branches the same way the branches' own resource sets are combined, so
+// that the exclusion a caller sees on the terminal ResolvedResource
+// reflects every exclusion branch that could subtract from a wildcard
+// match, not just the one closest to the wildcard.
+type ExcludedSubjectSet map[string]map[string]struct{}
+
+// NewExcludedSubjectSet creates an empty ExcludedSubjectSet.
+func NewExcludedSubjectSet() ExcludedSubjectSet {
+	return make(ExcludedSubjectSet)
+}
+
+// Subtract records that subjectID is excluded from resourceID's wildcard
+// match, as computed by an exclusion (`-`) branch.
+func (s ExcludedSubjectSet) Subtract(resourceID, subjectID string) {
+	subjects, ok := s[resourceID]
+	if !ok {
+		subjects = make(map[string]struct{})
+		s[resourceID] = subjects
+	}
+	subjects[subjectID] = struct{}{}
+}
+
+// Union combines s with other the way a union (`+`) branch combines its
+// children's accessible sets: each operand grants Full \ Excluded, and the
+// union as a whole grants access whenever either operand does. By De
+// Morgan's law that makes the union's excluded set the *intersection* of
+// the operands' excluded sets: a subject stays excluded from the result
+// only if both operands excluded it, since a subject excluded by just one
+// operand is still granted access through the other.
+func (s ExcludedSubjectSet) Union(other ExcludedSubjectSet) ExcludedSubjectSet {
+	return s.merge(other, func(inLeft, inRight bool) bool {
+		return inLeft && inRight
+	})
+}
+
+// Intersect combines s with other the way an intersection (`&`) branch
+// combines its children's accessible sets: access requires both operands
+// to grant it, so a subject excluded by either operand is excluded from
+// the result. By De Morgan's law that makes the intersection's excluded
+// set the *union* of the operands' excluded sets.
+func (s ExcludedSubjectSet) Intersect(other ExcludedSubjectSet) ExcludedSubjectSet {
+	return s.merge(other, func(inLeft, inRight bool) bool {
+		return inLeft || inRight
+	})
+}
+
+func (s ExcludedSubjectSet) merge(other ExcludedSubjectSet, keep func(inLeft, inRight bool) bool) ExcludedSubjectSet {
+	merged := NewExcludedSubjectSet()
+
+	resourceIDs := make(map[string]struct{}, len(s)+len(other))
+	for resourceID := range s {
+		resourceIDs[resourceID] = struct{}{}
+	}
+	for resourceID := range other {
+		resourceIDs[resourceID] = struct{}{}
+	}
+
+	for resourceID := range resourceIDs {
+		left := s[resourceID]
+		right := other[resourceID]
+
+		subjectIDs := make(map[string]struct{}, len(left)+len(right))
+		for subjectID := range left {
+			subjectIDs[subjectID] = struct{}{}
+		}
+		for subjectID := range right {
+			subjectIDs[subjectID] = struct{}{}
+		}
+
+		for subjectID := range subjectIDs {
+			_, inLeft := left[subjectID]
+			_, inRight := right[subjectID]
+			if keep(inLeft, inRight) {
+				merged.Subtract(resourceID, subjectID)
+			}
+		}
+	}
+
+	return merged
+}
+
+// ExcludedSubjectIDsFor returns the subject IDs excluded from resourceID,
+// in no particular order, or nil if none are.
+func (s ExcludedSubjectSet) ExcludedSubjectIDsFor(resourceID string) []string {
+	subjects, ok := s[resourceID]
+	if !ok || len(subjects) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(subjects))
+	for subjectID := range subjects {
+		ids = append(ids, subjectID)
+	}
+	return ids
+}
+
+// ApplyTo sets ExcludedSubjectIds on resolved from s, so the terminal
+// ResolvedResource sent to the caller carries every subject ID excluded
+// from its wildcard match by an exclusion branch anywhere in the
+// permission expression.
+func (s ExcludedSubjectSet) ApplyTo(resolved *v1.ResolvedResource) {
+	resolved.ExcludedSubjectIds = s.ExcludedSubjectIDsFor(resolved.ResourceId)
+}
@@ -0,0 +1,82 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	v1 "github.com/authzed/spicedb/pkg/proto/dispatch/v1"
+)
+
+func TestExcludedSubjectSetSubtractAndApply(t *testing.T) {
+	require := require.New(t)
+
+	s := NewExcludedSubjectSet()
+	s.Subtract("doc1", "tom")
+	s.Subtract("doc1", "sarah")
+
+	resolved := &v1.ResolvedResource{ResourceId: "doc1"}
+	s.ApplyTo(resolved)
+
+	require.ElementsMatch([]string{"tom", "sarah"}, resolved.ExcludedSubjectIds)
+}
+
+func TestExcludedSubjectSetApplyToUnexcludedResource(t *testing.T) {
+	require := require.New(t)
+
+	s := NewExcludedSubjectSet()
+	s.Subtract("doc1", "tom")
+
+	resolved := &v1.ResolvedResource{ResourceId: "doc2"}
+	s.ApplyTo(resolved)
+
+	require.Nil(resolved.ExcludedSubjectIds)
+}
+
+// TestExcludedSubjectSetUnionRequiresBothExclusions covers the `+`
+// combinator: each operand grants Full \ Excluded, and `+` grants access
+// if either operand does, so (by De Morgan) a subject stays excluded from
+// the union only if both operands excluded it.
+func TestExcludedSubjectSetUnionRequiresBothExclusions(t *testing.T) {
+	require := require.New(t)
+
+	left := NewExcludedSubjectSet()
+	left.Subtract("doc1", "tom")
+	left.Subtract("doc1", "sarah")
+
+	right := NewExcludedSubjectSet()
+	right.Subtract("doc1", "tom")
+
+	union := left.Union(right)
+	require.ElementsMatch([]string{"tom"}, union.ExcludedSubjectIDsFor("doc1"))
+}
+
+func TestExcludedSubjectSetUnionOfDisjointExclusionsIsEmpty(t *testing.T) {
+	require := require.New(t)
+
+	left := NewExcludedSubjectSet()
+	left.Subtract("doc1", "tom")
+
+	right := NewExcludedSubjectSet()
+	right.Subtract("doc1", "sarah")
+
+	union := left.Union(right)
+	require.Nil(union.ExcludedSubjectIDsFor("doc1"))
+}
+
+// TestExcludedSubjectSetIntersectKeepsEitherExclusion covers the `&`
+// combinator: access requires both operands to grant it, so (by De
+// Morgan) a subject excluded by either operand is excluded from the
+// intersection.
+func TestExcludedSubjectSetIntersectKeepsEitherExclusion(t *testing.T) {
+	require := require.New(t)
+
+	left := NewExcludedSubjectSet()
+	left.Subtract("doc1", "tom")
+
+	right := NewExcludedSubjectSet()
+	right.Subtract("doc1", "sarah")
+
+	intersected := left.Intersect(right)
+	require.ElementsMatch([]string{"tom", "sarah"}, intersected.ExcludedSubjectIDsFor("doc1"))
+}
@@ -30,6 +30,16 @@ func resolvedRes(resourceID string) *v1.ResolvedResource {
 	}
 }
 
+// resolvedResExcludingSubjects builds a ResolvedResource matched via a
+// wildcard grant, minus the subject IDs subtracted by an exclusion branch.
+func resolvedResExcludingSubjects(resourceID string, excludedSubjectIDs ...string) *v1.ResolvedResource {
+	return &v1.ResolvedResource{
+		ResourceId:         resourceID,
+		Permissionship:     v1.ResolvedResource_HAS_PERMISSION,
+		ExcludedSubjectIds: excludedSubjectIDs,
+	}
+}
+
 func TestSimpleLookupResources(t *testing.T) {
 	t.Parallel()
 
@@ -659,6 +669,57 @@ func TestLookupResourcesOverSchemaWithCursors(t *testing.T) {
 	}
 }
 
+// TestLookupResourcesWildcardWithExclusion ensures that a wildcard grant
+// combined with an exclusion surfaces the subtracted subject IDs on the
+// terminal ResolvedResource, so callers can answer "accessible to everyone
+// except X, Y" without a separate LookupSubjects call.
+func TestLookupResourcesWildcardWithExclusion(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	schema := `definition user {}
+
+	definition document {
+		relation viewer: user:*
+		relation banned: user
+		permission view = viewer - banned
+	}`
+
+	relationships := []tuple.Relationship{
+		tuple.MustParse("document:doc1#viewer@user:*"),
+		tuple.MustParse("document:doc1#banned@user:tom"),
+		tuple.MustParse("document:doc1#banned@user:sarah"),
+	}
+
+	dispatcher := NewLocalOnlyDispatcher(10, 100)
+	defer dispatcher.Close()
+
+	ds, err := memdb.NewMemdbDatastore(0, 0, memdb.DisableGC)
+	require.NoError(err)
+
+	ds, revision := testfixtures.DatastoreFromSchemaAndTestRelationships(ds, schema, relationships, require)
+
+	ctx := datastoremw.ContextWithHandle(context.Background())
+	require.NoError(datastoremw.SetInContext(ctx, ds))
+
+	stream := dispatch.NewCollectingDispatchStream[*v1.DispatchLookupResourcesResponse](ctx)
+	err = dispatcher.DispatchLookupResources(&v1.DispatchLookupResourcesRequest{
+		ObjectRelation: RR("document", "view").ToCoreRR(),
+		Subject:        ONR("user", "fred", "...").ToCoreONR(),
+		Metadata: &v1.ResolverMeta{
+			AtRevision:     revision.String(),
+			DepthRemaining: 50,
+		},
+		OptionalLimit: veryLargeLimit,
+	}, stream)
+	require.NoError(err)
+
+	foundResources, _, _, _ := processResults(stream)
+	require.Len(foundResources, 1)
+	require.Equal("doc1", foundResources[0].ResourceId)
+	require.ElementsMatch(resolvedResExcludingSubjects("doc1", "tom", "sarah").ExcludedSubjectIds, foundResources[0].ExcludedSubjectIds)
+}
+
 func TestLookupResourcesImmediateTimeout(t *testing.T) {
 	t.Parallel()
 
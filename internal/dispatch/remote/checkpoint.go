@@ -0,0 +1,91 @@
+package remote
+
+import (
+	"container/list"
+	"context"
+	"sync"
+
+	v1 "github.com/authzed/spicedb/pkg/proto/dispatch/v1"
+)
+
+// CursorCheckpointer persists the AfterResponseCursor of the last
+// DispatchLookupResources2Response successfully sent to a caller, keyed by
+// an opaque identifier for that call (typically the incoming request's
+// peer/stream identity). This lets the cluster dispatcher emit a
+// cursor-only sentinel response on cancellation, so a retrying caller can
+// resume from the last good point instead of restarting the whole
+// dispatch.
+type CursorCheckpointer interface {
+	// Save records cursor as the last checkpoint for key, replacing any
+	// previous value.
+	Save(ctx context.Context, key string, cursor *v1.Cursor)
+
+	// Load returns the last checkpoint saved for key, if any.
+	Load(ctx context.Context, key string) (*v1.Cursor, bool)
+}
+
+// lruEntry is a single (key, cursor) pair tracked by LRUCursorCheckpointer.
+type lruEntry struct {
+	key    string
+	cursor *v1.Cursor
+}
+
+// LRUCursorCheckpointer is the default in-memory CursorCheckpointer. It
+// retains the most recently touched maxEntries checkpoints, evicting the
+// least recently used entry once full.
+type LRUCursorCheckpointer struct {
+	maxEntries int
+
+	mu      sync.Mutex
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+// NewLRUCursorCheckpointer creates an in-memory CursorCheckpointer that
+// retains at most maxEntries checkpoints.
+func NewLRUCursorCheckpointer(maxEntries int) *LRUCursorCheckpointer {
+	if maxEntries <= 0 {
+		maxEntries = 10_000
+	}
+
+	return &LRUCursorCheckpointer{
+		maxEntries: maxEntries,
+		order:      list.New(),
+		entries:    make(map[string]*list.Element, maxEntries),
+	}
+}
+
+func (c *LRUCursorCheckpointer) Save(_ context.Context, key string, cursor *v1.Cursor) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		elem.Value.(*lruEntry).cursor = cursor
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	elem := c.order.PushFront(&lruEntry{key: key, cursor: cursor})
+	c.entries[key] = elem
+
+	if c.order.Len() > c.maxEntries {
+		oldest := c.order.Back()
+		if oldest != nil {
+			c.order.Remove(oldest)
+			delete(c.entries, oldest.Value.(*lruEntry).key)
+		}
+	}
+}
+
+func (c *LRUCursorCheckpointer) Load(_ context.Context, key string) (*v1.Cursor, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	c.order.MoveToFront(elem)
+	return elem.Value.(*lruEntry).cursor, true
+}
@@ -0,0 +1,81 @@
+package remote
+
+import (
+	"context"
+	"errors"
+
+	v1 "github.com/authzed/spicedb/pkg/proto/dispatch/v1"
+)
+
+// CheckpointedStream drives a streaming dispatch call (DispatchLookupResources2
+// and friends) so that a checkpointer actually gets used: it loads a saved
+// cursor to resume from before the call starts, saves the cursor carried by
+// every response as it is forwarded, and, if the caller cancels mid-stream,
+// sends one final cursor-only response instead of simply erroring out, so a
+// retry can resume from the last good point. Without this, Save/Load were
+// only ever called directly by tests.
+type CheckpointedStream[T any] struct {
+	checkpointer CursorCheckpointer
+	cursorOf     func(T) *v1.Cursor
+	withCursor   func(cursor *v1.Cursor) T
+}
+
+// NewCheckpointedStream creates a CheckpointedStream backed by checkpointer.
+// cursorOf extracts the AfterResponseCursor from a single response; withCursor
+// builds the cursor-only sentinel response sent on cancellation.
+func NewCheckpointedStream[T any](checkpointer CursorCheckpointer, cursorOf func(T) *v1.Cursor, withCursor func(*v1.Cursor) T) *CheckpointedStream[T] {
+	return &CheckpointedStream[T]{checkpointer: checkpointer, cursorOf: cursorOf, withCursor: withCursor}
+}
+
+// ErrCheckpointedAndCanceled wraps the triggering cancellation error once a
+// cursor-only sentinel has been sent, so callers can tell "we resumed
+// cleanly" apart from a hard failure while still propagating the original
+// cause.
+var ErrCheckpointedAndCanceled = errors.New("stream canceled after emitting a resume checkpoint")
+
+// ResumeCursor returns the cursor previously checkpointed for key, if
+// any, so the caller can pass it as the streaming call's starting cursor
+// instead of replaying from the beginning.
+func (c *CheckpointedStream[T]) ResumeCursor(ctx context.Context, key string) (*v1.Cursor, bool) {
+	return c.checkpointer.Load(ctx, key)
+}
+
+// Run calls produce once, forwarding each response it yields to send and,
+// once send succeeds, checkpointing that response's cursor under key. If
+// ctx is canceled mid-stream and a checkpointed cursor exists, Run sends one
+// final sentinel response built from it via withCursor, then returns an
+// error wrapping ErrCheckpointedAndCanceled; with no cursor to resume from,
+// it returns the cancellation error alone.
+func (c *CheckpointedStream[T]) Run(ctx context.Context, key string, produce func(context.Context, func(T) error) error, send func(T) error) error {
+	var lastCursor *v1.Cursor
+
+	err := produce(ctx, func(resp T) error {
+		if err := send(resp); err != nil {
+			return err
+		}
+		if cursor := c.cursorOf(resp); cursor != nil {
+			lastCursor = cursor
+			c.checkpointer.Save(ctx, key, cursor)
+		}
+		return nil
+	})
+	if err == nil {
+		return nil
+	}
+
+	if ctx.Err() == nil {
+		return err
+	}
+
+	if lastCursor == nil {
+		lastCursor, _ = c.checkpointer.Load(ctx, key)
+	}
+	if lastCursor == nil {
+		return err
+	}
+	if sendErr := send(c.withCursor(lastCursor)); sendErr != nil {
+		return sendErr
+	}
+
+	return errors.Join(ErrCheckpointedAndCanceled, err)
+}
@@ -0,0 +1,122 @@
+package remote
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	v1 "github.com/authzed/spicedb/pkg/proto/dispatch/v1"
+)
+
+type fakeResponse struct {
+	resourceID string
+	cursor     *v1.Cursor
+}
+
+func newCheckpointedFakeStream(checkpointer CursorCheckpointer) *CheckpointedStream[fakeResponse] {
+	return NewCheckpointedStream[fakeResponse](
+		checkpointer,
+		func(r fakeResponse) *v1.Cursor { return r.cursor },
+		func(cursor *v1.Cursor) fakeResponse { return fakeResponse{cursor: cursor} },
+	)
+}
+
+func TestCheckpointedStreamSavesCursorPerResponse(t *testing.T) {
+	require := require.New(t)
+
+	checkpointer := NewLRUCursorCheckpointer(10)
+	stream := newCheckpointedFakeStream(checkpointer)
+
+	var sent []fakeResponse
+	err := stream.Run(context.Background(), "call-1",
+		func(_ context.Context, yield func(fakeResponse) error) error {
+			for i := 0; i < 3; i++ {
+				if err := yield(fakeResponse{
+					resourceID: "doc",
+					cursor:     &v1.Cursor{DispatchVersion: uint32(i + 1)},
+				}); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		func(r fakeResponse) error {
+			sent = append(sent, r)
+			return nil
+		},
+	)
+
+	require.NoError(err)
+	require.Len(sent, 3)
+
+	cursor, ok := checkpointer.Load(context.Background(), "call-1")
+	require.True(ok)
+	require.Equal(uint32(3), cursor.DispatchVersion)
+}
+
+func TestCheckpointedStreamEmitsSentinelOnCancel(t *testing.T) {
+	require := require.New(t)
+
+	checkpointer := NewLRUCursorCheckpointer(10)
+	stream := newCheckpointedFakeStream(checkpointer)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var sent []fakeResponse
+	err := stream.Run(ctx, "call-2",
+		func(ctx context.Context, yield func(fakeResponse) error) error {
+			if err := yield(fakeResponse{resourceID: "first", cursor: &v1.Cursor{DispatchVersion: 1}}); err != nil {
+				return err
+			}
+			cancel()
+			return ctx.Err()
+		},
+		func(r fakeResponse) error {
+			sent = append(sent, r)
+			return nil
+		},
+	)
+
+	require.ErrorIs(err, ErrCheckpointedAndCanceled)
+	require.ErrorIs(err, context.Canceled)
+	require.Len(sent, 2, "should forward the real response plus a cursor-only sentinel")
+	require.Equal(uint32(1), sent[1].cursor.DispatchVersion)
+}
+
+func TestCheckpointedStreamResumesFromPriorCheckpoint(t *testing.T) {
+	require := require.New(t)
+
+	checkpointer := NewLRUCursorCheckpointer(10)
+	checkpointer.Save(context.Background(), "call-3", &v1.Cursor{DispatchVersion: 7})
+
+	stream := newCheckpointedFakeStream(checkpointer)
+
+	cursor, ok := stream.ResumeCursor(context.Background(), "call-3")
+	require.True(ok)
+	require.Equal(uint32(7), cursor.DispatchVersion)
+}
+
+func TestCheckpointedStreamPropagatesNonCancelError(t *testing.T) {
+	require := require.New(t)
+
+	checkpointer := NewLRUCursorCheckpointer(10)
+	stream := newCheckpointedFakeStream(checkpointer)
+
+	boom := errors.New("boom")
+	var sent []fakeResponse
+	err := stream.Run(context.Background(), "call-4",
+		func(context.Context, func(fakeResponse) error) error {
+			return boom
+		},
+		func(r fakeResponse) error {
+			sent = append(sent, r)
+			return nil
+		},
+	)
+
+	require.ErrorIs(err, boom)
+	require.NotErrorIs(err, ErrCheckpointedAndCanceled)
+	require.Empty(sent)
+}
@@ -0,0 +1,51 @@
+package remote
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	v1 "github.com/authzed/spicedb/pkg/proto/dispatch/v1"
+)
+
+func TestLRUCursorCheckpointerSaveAndLoad(t *testing.T) {
+	require := require.New(t)
+
+	checkpointer := NewLRUCursorCheckpointer(2)
+	ctx := context.Background()
+
+	_, found := checkpointer.Load(ctx, "call-1")
+	require.False(found)
+
+	cursor := &v1.Cursor{Sections: []string{"a"}, DispatchVersion: 1}
+	checkpointer.Save(ctx, "call-1", cursor)
+
+	loaded, found := checkpointer.Load(ctx, "call-1")
+	require.True(found)
+	require.Equal(cursor, loaded)
+}
+
+func TestLRUCursorCheckpointerEvictsLeastRecentlyUsed(t *testing.T) {
+	require := require.New(t)
+
+	checkpointer := NewLRUCursorCheckpointer(2)
+	ctx := context.Background()
+
+	checkpointer.Save(ctx, "call-1", &v1.Cursor{DispatchVersion: 1})
+	checkpointer.Save(ctx, "call-2", &v1.Cursor{DispatchVersion: 2})
+
+	// Touch call-1 so call-2 becomes the least recently used entry.
+	_, _ = checkpointer.Load(ctx, "call-1")
+
+	checkpointer.Save(ctx, "call-3", &v1.Cursor{DispatchVersion: 3})
+
+	_, found := checkpointer.Load(ctx, "call-2")
+	require.False(found)
+
+	_, found = checkpointer.Load(ctx, "call-1")
+	require.True(found)
+
+	_, found = checkpointer.Load(ctx, "call-3")
+	require.True(found)
+}
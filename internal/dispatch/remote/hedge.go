@@ -0,0 +1,83 @@
+package remote
+
+import (
+	"context"
+	"time"
+)
+
+// HedgedUnaryDispatcher races a secondary dispatch against an in-flight
+// primary once the primary has run long enough to look unusually slow for
+// that secondary, using estimator to decide when "long enough" is. This is
+// the actual hedging behavior HedgingConfig/latencyEstimator exist to
+// drive: without it, observing latency and deciding ShouldHedge never
+// affected which RPCs were sent.
+type HedgedUnaryDispatcher[T any] struct {
+	estimator *latencyEstimator
+	poll      time.Duration
+}
+
+// NewHedgedUnaryDispatcher creates a HedgedUnaryDispatcher governed by
+// config. poll controls how often ShouldHedge is re-checked while the
+// primary is still in flight; it does not need to be configured by
+// callers that are fine with the default of 1ms.
+func NewHedgedUnaryDispatcher[T any](config HedgingConfig, poll time.Duration) *HedgedUnaryDispatcher[T] {
+	if poll <= 0 {
+		poll = time.Millisecond
+	}
+	return &HedgedUnaryDispatcher[T]{
+		estimator: newLatencyEstimator(config),
+		poll:      poll,
+	}
+}
+
+// unaryResult carries a single dispatch attempt's outcome back to Dispatch
+// over a channel, so the primary and any hedge can race without either
+// blocking the other.
+type unaryResult[T any] struct {
+	value T
+	err   error
+}
+
+// Dispatch calls primary, and additionally calls secondary once the
+// primary has been in flight longer than estimator's current hedge
+// threshold, returning whichever of the two completes first. secondary is
+// never called if primary finishes before hedging would have fired. The
+// primary's latency (start to completion, regardless of which attempt
+// wins) is fed back into estimator so the threshold adapts over time.
+func (h *HedgedUnaryDispatcher[T]) Dispatch(ctx context.Context, primary, secondary func(context.Context) (T, error)) (T, error) {
+	start := time.Now()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan unaryResult[T], 2)
+
+	go func() {
+		value, err := primary(ctx)
+		h.estimator.Observe(time.Since(start))
+		results <- unaryResult[T]{value, err}
+	}()
+
+	ticker := time.NewTicker(h.poll)
+	defer ticker.Stop()
+
+	hedged := false
+	for {
+		select {
+		case result := <-results:
+			return result.value, result.err
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		case <-ticker.C:
+			if hedged || !h.estimator.ShouldHedge(time.Since(start)) {
+				continue
+			}
+			hedged = true
+			go func() {
+				value, err := secondary(ctx)
+				results <- unaryResult[T]{value, err}
+			}()
+		}
+	}
+}
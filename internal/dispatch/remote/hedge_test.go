@@ -0,0 +1,68 @@
+package remote
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// primeEstimator feeds samples directly to d's estimator so ShouldHedge
+// reflects a trained quantile without looping real dispatch calls.
+func primeEstimator[T any](d *HedgedUnaryDispatcher[T], latency time.Duration, count int) {
+	for i := 0; i < count; i++ {
+		d.estimator.Observe(latency)
+	}
+}
+
+func TestHedgedUnaryDispatcherDoesNotHedgeFastPrimary(t *testing.T) {
+	require := require.New(t)
+
+	d := NewHedgedUnaryDispatcher[string](HedgingConfig{MinimumSamples: 5}, time.Millisecond)
+	primeEstimator(d, 50*time.Millisecond, 10)
+
+	secondaryCalled := false
+	result, err := d.Dispatch(context.Background(),
+		func(context.Context) (string, error) { return "primary", nil },
+		func(context.Context) (string, error) { secondaryCalled = true; return "secondary", nil },
+	)
+
+	require.NoError(err)
+	require.Equal("primary", result)
+	require.False(secondaryCalled, "secondary should not be raced when the primary returns before the hedge threshold")
+}
+
+func TestHedgedUnaryDispatcherHedgesSlowPrimary(t *testing.T) {
+	require := require.New(t)
+
+	d := NewHedgedUnaryDispatcher[string](HedgingConfig{MinimumSamples: 5}, time.Millisecond)
+	primeEstimator(d, time.Millisecond, 10)
+
+	result, err := d.Dispatch(context.Background(),
+		func(ctx context.Context) (string, error) {
+			<-ctx.Done()
+			return "", ctx.Err()
+		},
+		func(context.Context) (string, error) { return "secondary", nil },
+	)
+
+	require.NoError(err)
+	require.Equal("secondary", result, "secondary should win once the primary has run well past its hedge threshold")
+}
+
+func TestHedgedUnaryDispatcherPropagatesPrimaryError(t *testing.T) {
+	require := require.New(t)
+
+	d := NewHedgedUnaryDispatcher[string](HedgingConfig{MinimumSamples: 5}, time.Millisecond)
+	primeEstimator(d, 50*time.Millisecond, 10)
+
+	boom := errors.New("boom")
+	_, err := d.Dispatch(context.Background(),
+		func(context.Context) (string, error) { return "", boom },
+		func(context.Context) (string, error) { return "secondary", nil },
+	)
+
+	require.ErrorIs(err, boom)
+}
@@ -0,0 +1,98 @@
+package remote
+
+import (
+	"sync"
+	"time"
+
+	hdrhistogram "github.com/HdrHistogram/hdrhistogram-go"
+)
+
+// HedgingConfig controls adaptive request hedging for unary dispatches
+// (DispatchCheck, DispatchExpand) against a secondary. Unlike the
+// always-fire-parallel behavior used for DispatchLookupResources2, hedging
+// only fires the secondary once the primary has been observed to be
+// unusually slow, trading a small amount of duplicate work for a large cut
+// in tail latency.
+type HedgingConfig struct {
+	// Quantile is the latency percentile, in the range (0, 1), above which
+	// the primary is considered slow enough to warrant racing a secondary.
+	// Defaults to 0.99.
+	Quantile float64
+
+	// MinimumSamples is how many primary RTT samples must have been
+	// observed for a secondary before its quantile estimate is trusted.
+	// Below this count, hedging never fires for that secondary. Defaults
+	// to 100.
+	MinimumSamples int64
+
+	// MaxConcurrentHedges is the number of secondaries that may be raced
+	// in parallel for a single request. Defaults to 1. HedgedUnaryDispatcher
+	// does not read it: it races at most one secondary per call, whatever
+	// this is set to.
+	MaxConcurrentHedges int
+
+	// CountHedgedWinsInMetrics names whether a hedge that wins the race is
+	// counted in the Prometheus secondary-dispatch counters.
+	// HedgedUnaryDispatcher does not read it and records no metrics.
+	CountHedgedWinsInMetrics bool
+}
+
+func (c HedgingConfig) withDefaults() HedgingConfig {
+	if c.Quantile <= 0 || c.Quantile >= 1 {
+		c.Quantile = 0.99
+	}
+	if c.MinimumSamples <= 0 {
+		c.MinimumSamples = 100
+	}
+	if c.MaxConcurrentHedges <= 0 {
+		c.MaxConcurrentHedges = 1
+	}
+	return c
+}
+
+// latencyEstimator maintains a histogram of every primary RTT observed for
+// a single secondary since the estimator was created (samples are never
+// aged out), so that the cluster dispatcher can decide whether
+// the primary's current in-flight latency is slow enough to warrant racing
+// that secondary.
+type latencyEstimator struct {
+	config HedgingConfig
+
+	mu      sync.Mutex
+	hist    *hdrhistogram.Histogram
+	samples int64
+}
+
+// newLatencyEstimator creates a latencyEstimator that tracks RTTs between 1
+// microsecond and 1 minute, at 3 significant digits of precision.
+func newLatencyEstimator(config HedgingConfig) *latencyEstimator {
+	return &latencyEstimator{
+		config: config.withDefaults(),
+		hist:   hdrhistogram.New(1, (60 * time.Second).Microseconds(), 3),
+	}
+}
+
+// Observe records a single primary RTT.
+func (le *latencyEstimator) Observe(rtt time.Duration) {
+	le.mu.Lock()
+	defer le.mu.Unlock()
+
+	_ = le.hist.RecordValue(rtt.Microseconds())
+	le.samples++
+}
+
+// ShouldHedge reports whether elapsed, the time the primary has been
+// in-flight so far, has already exceeded the configured latency quantile
+// for this secondary. It returns false until MinimumSamples have been
+// observed, so a cold secondary never hedges on noise.
+func (le *latencyEstimator) ShouldHedge(elapsed time.Duration) bool {
+	le.mu.Lock()
+	defer le.mu.Unlock()
+
+	if le.samples < le.config.MinimumSamples {
+		return false
+	}
+
+	threshold := le.hist.ValueAtQuantile(le.config.Quantile * 100)
+	return elapsed.Microseconds() >= threshold
+}
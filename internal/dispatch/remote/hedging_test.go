@@ -0,0 +1,37 @@
+package remote
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatencyEstimatorRequiresMinimumSamples(t *testing.T) {
+	require := require.New(t)
+
+	estimator := newLatencyEstimator(HedgingConfig{MinimumSamples: 10})
+	for i := 0; i < 9; i++ {
+		estimator.Observe(10 * time.Millisecond)
+	}
+
+	require.False(estimator.ShouldHedge(1 * time.Second))
+
+	estimator.Observe(10 * time.Millisecond)
+	require.True(estimator.ShouldHedge(1 * time.Second))
+}
+
+func TestLatencyEstimatorTracksQuantile(t *testing.T) {
+	require := require.New(t)
+
+	estimator := newLatencyEstimator(HedgingConfig{MinimumSamples: 100, Quantile: 0.99})
+	for i := 0; i < 99; i++ {
+		estimator.Observe(10 * time.Millisecond)
+	}
+	estimator.Observe(500 * time.Millisecond)
+
+	// p99 of these 100 samples is the 99th-smallest, 10ms, so the single
+	// 500ms outlier does not raise the hedge threshold.
+	require.False(estimator.ShouldHedge(5 * time.Millisecond))
+	require.True(estimator.ShouldHedge(600 * time.Millisecond))
+}
@@ -0,0 +1,128 @@
+package remote
+
+import (
+	"context"
+	"sync"
+
+	v1 "github.com/authzed/spicedb/pkg/proto/dispatch/v1"
+)
+
+// SchemaChangePublisher is the server side of WatchSchemaChanges: it fans
+// out a SchemaChangedEvent, raised locally whenever a schema write
+// commits, to every peer currently subscribed. Without this, SchemaWatcher
+// (the client side) had no real counterpart ever publishing an event for
+// it to receive.
+type SchemaChangePublisher struct {
+	v1.UnimplementedDispatchServiceServer
+
+	mu          sync.Mutex
+	subscribers map[chan *v1.SchemaChangedEvent]struct{}
+}
+
+// NewSchemaChangePublisher creates an empty SchemaChangePublisher.
+func NewSchemaChangePublisher() *SchemaChangePublisher {
+	return &SchemaChangePublisher{
+		subscribers: make(map[chan *v1.SchemaChangedEvent]struct{}),
+	}
+}
+
+// PublishSchemaChange notifies every currently-subscribed peer that
+// namespace changed at revision. Call this from wherever schema writes are
+// committed.
+func (p *SchemaChangePublisher) PublishSchemaChange(namespace, revision string) {
+	event := &v1.SchemaChangedEvent{Namespace: namespace, Revision: revision}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for subscriber := range p.subscribers {
+		// Never block a publish on a slow subscriber: an event that does
+		// not fit in a subscriber's buffer is dropped for that subscriber
+		// and is not sent to it later.
+		select {
+		case subscriber <- event:
+		default:
+		}
+	}
+}
+
+// WatchSchemaChanges implements v1.DispatchServiceServer, streaming every
+// SchemaChangedEvent published after the caller subscribes until the
+// stream's context is done.
+func (p *SchemaChangePublisher) WatchSchemaChanges(_ *v1.WatchSchemaChangesRequest, stream v1.DispatchService_WatchSchemaChangesServer) error {
+	subscriber := make(chan *v1.SchemaChangedEvent, 16)
+
+	p.mu.Lock()
+	p.subscribers[subscriber] = struct{}{}
+	p.mu.Unlock()
+
+	defer func() {
+		p.mu.Lock()
+		delete(p.subscribers, subscriber)
+		p.mu.Unlock()
+	}()
+
+	for {
+		select {
+		case event := <-subscriber:
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
+
+// namespaceCacheEntry is a single cached value alongside the namespace it
+// was computed for, so InvalidateNamespace can evict by namespace without
+// the cache needing to parse its own keys back apart.
+type namespaceCacheEntry struct {
+	namespace string
+	value     any
+}
+
+// NamespaceCache is a minimal check/lookup result cache keyed by an
+// opaque caller-provided key, scoped per namespace so a SchemaChangedEvent
+// can evict exactly the entries it invalidates instead of flushing
+// everything. It satisfies CacheInvalidator.
+type NamespaceCache struct {
+	mu      sync.Mutex
+	entries map[string]namespaceCacheEntry
+}
+
+// NewNamespaceCache creates an empty NamespaceCache.
+func NewNamespaceCache() *NamespaceCache {
+	return &NamespaceCache{entries: make(map[string]namespaceCacheEntry)}
+}
+
+// Set records value under key, scoped to namespace.
+func (c *NamespaceCache) Set(ctx context.Context, namespace, key string, value any) {
+	_ = ctx
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = namespaceCacheEntry{namespace: namespace, value: value}
+}
+
+// Get returns the value previously Set for key, if any.
+func (c *NamespaceCache) Get(key string) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	return entry.value, true
+}
+
+// InvalidateNamespace evicts every cached entry scoped to namespace.
+func (c *NamespaceCache) InvalidateNamespace(namespace string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, entry := range c.entries {
+		if entry.namespace == namespace {
+			delete(c.entries, key)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package remote
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	v1 "github.com/authzed/spicedb/pkg/proto/dispatch/v1"
+)
+
+func TestSchemaChangePublisherEndToEndInvalidatesCache(t *testing.T) {
+	require := require.New(t)
+
+	publisher := NewSchemaChangePublisher()
+	conn := connectionForDispatching(t, publisher)
+
+	cache := NewNamespaceCache()
+	cache.Set(context.Background(), "document", "check:doc1#view@user:tom", true)
+
+	watcher := NewSchemaWatcher([]v1.DispatchServiceClient{v1.NewDispatchServiceClient(conn)}, cache)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go watcher.Start(ctx)
+
+	// Give the watcher a moment to establish its subscription before
+	// publishing, since the publisher only fans events out to peers that
+	// are already subscribed when PublishSchemaChange is called.
+	require.Eventually(func() bool {
+		publisher.mu.Lock()
+		defer publisher.mu.Unlock()
+		return len(publisher.subscribers) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	publisher.PublishSchemaChange("document", "1")
+
+	require.Eventually(func() bool {
+		_, found := cache.Get("check:doc1#view@user:tom")
+		return !found
+	}, time.Second, 10*time.Millisecond, "schema change for the document namespace should evict its cached entry")
+}
@@ -0,0 +1,92 @@
+package remote
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	v1 "github.com/authzed/spicedb/pkg/proto/dispatch/v1"
+)
+
+// CacheInvalidator evicts cached check/lookup entries for a namespace. The
+// check/lookup cache implementation satisfies this so that a SchemaWatcher
+// can proactively evict entries made stale by a peer's schema write,
+// instead of waiting for them to TTL out.
+type CacheInvalidator interface {
+	InvalidateNamespace(namespace string)
+}
+
+// schemaWatchInitialBackoff and schemaWatchMaxBackoff bound the
+// reconnect/backoff loop used when a peer's WatchSchemaChanges stream
+// fails or ends.
+const (
+	schemaWatchInitialBackoff = 100 * time.Millisecond
+	schemaWatchMaxBackoff     = 30 * time.Second
+)
+
+// SchemaWatcher subscribes to the WatchSchemaChanges stream of every peer
+// in a SpiceDB cluster and evicts the affected namespace from invalidator
+// whenever a peer reports a SchemaChangedEvent, removing the current
+// "TTL it out" staleness window for schema edits.
+type SchemaWatcher struct {
+	peers       []v1.DispatchServiceClient
+	invalidator CacheInvalidator
+}
+
+// NewSchemaWatcher creates a SchemaWatcher that subscribes to peers and
+// evicts entries from invalidator as schema changes arrive.
+func NewSchemaWatcher(peers []v1.DispatchServiceClient, invalidator CacheInvalidator) *SchemaWatcher {
+	return &SchemaWatcher{peers: peers, invalidator: invalidator}
+}
+
+// Start subscribes to every configured peer and blocks until ctx is
+// canceled. Each peer subscription runs in its own goroutine and
+// reconnects with exponential backoff if its stream fails or ends.
+func (w *SchemaWatcher) Start(ctx context.Context) {
+	for _, peer := range w.peers {
+		go w.watchPeer(ctx, peer)
+	}
+	<-ctx.Done()
+}
+
+func (w *SchemaWatcher) watchPeer(ctx context.Context, peer v1.DispatchServiceClient) {
+	backoff := schemaWatchInitialBackoff
+
+	for ctx.Err() == nil {
+		stream, err := peer.WatchSchemaChanges(ctx, &v1.WatchSchemaChangesRequest{})
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to subscribe to peer schema changes", "error", err)
+			backoff = w.sleepAndBackoff(ctx, backoff)
+			continue
+		}
+
+		backoff = schemaWatchInitialBackoff
+		for {
+			event, err := stream.Recv()
+			if err != nil {
+				break
+			}
+
+			w.invalidator.InvalidateNamespace(event.Namespace)
+		}
+
+		if ctx.Err() != nil {
+			return
+		}
+		backoff = w.sleepAndBackoff(ctx, backoff)
+	}
+}
+
+func (w *SchemaWatcher) sleepAndBackoff(ctx context.Context, current time.Duration) time.Duration {
+	select {
+	case <-time.After(current):
+	case <-ctx.Done():
+		return current
+	}
+
+	next := current * 2
+	if next > schemaWatchMaxBackoff {
+		return schemaWatchMaxBackoff
+	}
+	return next
+}
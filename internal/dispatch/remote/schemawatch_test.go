@@ -0,0 +1,72 @@
+package remote
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	v1 "github.com/authzed/spicedb/pkg/proto/dispatch/v1"
+)
+
+// fakeSchemaChangeSvc streams a fixed set of SchemaChangedEvents to every
+// subscriber and then ends the stream, so the test can assert on what a
+// single event does to the receiving invalidator.
+type fakeSchemaChangeSvc struct {
+	v1.UnimplementedDispatchServiceServer
+
+	events []*v1.SchemaChangedEvent
+}
+
+func (f *fakeSchemaChangeSvc) WatchSchemaChanges(_ *v1.WatchSchemaChangesRequest, srv v1.DispatchService_WatchSchemaChangesServer) error {
+	for _, event := range f.events {
+		if err := srv.Send(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordingInvalidator records every namespace it was asked to invalidate.
+type recordingInvalidator struct {
+	mu          sync.Mutex
+	invalidated []string
+}
+
+func (r *recordingInvalidator) InvalidateNamespace(namespace string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.invalidated = append(r.invalidated, namespace)
+}
+
+func (r *recordingInvalidator) snapshot() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.invalidated...)
+}
+
+func TestSchemaWatcherInvalidatesOnPeerEvent(t *testing.T) {
+	require := require.New(t)
+
+	conn := connectionForDispatching(t, &fakeSchemaChangeSvc{
+		events: []*v1.SchemaChangedEvent{
+			{Namespace: "document", Revision: "1"},
+		},
+	})
+
+	invalidator := &recordingInvalidator{}
+	watcher := NewSchemaWatcher([]v1.DispatchServiceClient{v1.NewDispatchServiceClient(conn)}, invalidator)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go watcher.Start(ctx)
+
+	require.Eventually(func() bool {
+		return len(invalidator.snapshot()) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	require.Equal([]string{"document"}, invalidator.snapshot())
+}
@@ -0,0 +1,60 @@
+package remote
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// SecondaryPool dispatches a call to one of a named set of secondaries,
+// chosen by a SecondarySelector, and feeds the observed latency back into
+// it. This is the real call site LoadAwareSecondarySelector was missing:
+// without it, Select/Observe/BeginRequest were only ever exercised by
+// their own unit tests.
+type SecondaryPool[T any] struct {
+	selector   SecondarySelector
+	candidates map[string]func(context.Context) (T, error)
+	names      []string
+}
+
+// NewSecondaryPool creates a SecondaryPool that chooses among candidates
+// using selector. The CEL expression ordering fallback
+// SecondarySelector.Select documents is determined by the names slice
+// passed to Dispatch; when Dispatch is given no names, it uses every
+// candidate in this map's iteration order, which is not defined.
+func NewSecondaryPool[T any](selector SecondarySelector, candidates map[string]func(context.Context) (T, error)) *SecondaryPool[T] {
+	names := make([]string, 0, len(candidates))
+	for name := range candidates {
+		names = append(names, name)
+	}
+	return &SecondaryPool[T]{selector: selector, candidates: candidates, names: names}
+}
+
+// Dispatch selects a secondary from names (typically a DispatchExpr's
+// candidate list, in its own preference order) via the pool's selector,
+// invokes it, and records the observed latency back into the selector so
+// future selections account for it.
+func (p *SecondaryPool[T]) Dispatch(ctx context.Context, names []string) (T, error) {
+	var zero T
+
+	if len(names) == 0 {
+		names = p.names
+	}
+
+	name, ok := p.selector.Select(names)
+	if !ok {
+		return zero, fmt.Errorf("no secondary candidates available")
+	}
+
+	call, ok := p.candidates[name]
+	if !ok {
+		return zero, fmt.Errorf("secondary selector chose unknown candidate %q", name)
+	}
+
+	start := time.Now()
+	done := p.selector.BeginRequest(name)
+	value, err := call(ctx)
+	done(time.Since(start))
+
+	return value, err
+}
@@ -0,0 +1,83 @@
+package remote
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecondaryPoolDispatchesToSelectedCandidate(t *testing.T) {
+	require := require.New(t)
+
+	selector := NewLoadAwareSecondarySelector()
+	selector.Observe("fast", 5*time.Millisecond)
+	selector.Observe("slow", 100*time.Millisecond)
+
+	var fastCalls, slowCalls int
+	pool := NewSecondaryPool(selector, map[string]func(context.Context) (string, error){
+		"fast": func(context.Context) (string, error) {
+			fastCalls++
+			return "fast", nil
+		},
+		"slow": func(context.Context) (string, error) {
+			slowCalls++
+			return "slow", nil
+		},
+	})
+
+	for i := 0; i < 100; i++ {
+		value, err := pool.Dispatch(context.Background(), []string{"fast", "slow"})
+		require.NoError(err)
+		require.NotEmpty(value)
+	}
+
+	require.Greater(fastCalls, slowCalls, "selector should route most calls to the faster candidate")
+}
+
+func TestSecondaryPoolObservesLatencyFromRealCalls(t *testing.T) {
+	require := require.New(t)
+
+	selector := NewLoadAwareSecondarySelector()
+	pool := NewSecondaryPool(selector, map[string]func(context.Context) (string, error){
+		"only": func(context.Context) (string, error) {
+			return "value", nil
+		},
+	})
+
+	value, err := pool.Dispatch(context.Background(), []string{"only"})
+	require.NoError(err)
+	require.Equal("value", value)
+
+	selector.mu.Lock()
+	samples := selector.stats["only"].samples
+	selector.mu.Unlock()
+	require.Equal(int64(1), samples, "Dispatch should feed the real call's latency back into the selector")
+}
+
+func TestSecondaryPoolPropagatesCandidateError(t *testing.T) {
+	require := require.New(t)
+
+	selector := NewLoadAwareSecondarySelector()
+	boom := errors.New("boom")
+	pool := NewSecondaryPool(selector, map[string]func(context.Context) (string, error){
+		"only": func(context.Context) (string, error) {
+			return "", boom
+		},
+	})
+
+	_, err := pool.Dispatch(context.Background(), []string{"only"})
+	require.ErrorIs(err, boom)
+}
+
+func TestSecondaryPoolNoCandidates(t *testing.T) {
+	require := require.New(t)
+
+	selector := NewLoadAwareSecondarySelector()
+	pool := NewSecondaryPool[string](selector, nil)
+
+	_, err := pool.Dispatch(context.Background(), nil)
+	require.Error(err)
+}
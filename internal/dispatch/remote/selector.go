@@ -0,0 +1,151 @@
+package remote
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// SecondarySelector picks which of the candidate secondaries returned by a
+// DispatchExpr a given unary or streaming dispatch should be sent to. It is
+// exposed as an interface on ClusterDispatcherConfig so operators can swap
+// in custom policies (round-robin, power-of-two-choices, a fixed weighting,
+// etc.) instead of always taking the CEL expression's first candidate.
+type SecondarySelector interface {
+	// Select picks one of candidateNames (the CEL expression's result for
+	// this call) to dispatch to. It returns false if candidateNames is
+	// empty.
+	Select(candidateNames []string) (string, bool)
+
+	// Observe records that a request to name completed in latency.
+	Observe(name string, latency time.Duration)
+
+	// BeginRequest marks a request to name as in-flight and returns a
+	// function to call once it completes, which both decrements the
+	// in-flight count and records the observed latency.
+	BeginRequest(name string) func(latency time.Duration)
+}
+
+// secondaryStats is the exponentially weighted moving average of latency,
+// plus the current in-flight count, tracked per secondary name.
+type secondaryStats struct {
+	ewmaLatency time.Duration
+	inFlight    int
+	samples     int64
+}
+
+// LoadAwareSecondarySelector chooses among a DispatchExpr's candidate
+// secondaries using power-of-two-choices over ewmaLatency * (1 + inflight),
+// rather than always picking the expression's first candidate. Until at
+// least one sample has been observed for every candidate in a given call,
+// it falls back to the expression's own ordering, so a cold cluster
+// behaves exactly like the static CEL-expression selection it replaces.
+type LoadAwareSecondarySelector struct {
+	mu    sync.Mutex
+	stats map[string]*secondaryStats
+	rand  *rand.Rand
+}
+
+// NewLoadAwareSecondarySelector creates a LoadAwareSecondarySelector.
+func NewLoadAwareSecondarySelector() *LoadAwareSecondarySelector {
+	return &LoadAwareSecondarySelector{
+		stats: make(map[string]*secondaryStats),
+		// #nosec G404 -- used only to break ties between candidates, not for anything security-sensitive.
+		rand: rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+func (s *LoadAwareSecondarySelector) statsFor(name string) *secondaryStats {
+	st, ok := s.stats[name]
+	if !ok {
+		st = &secondaryStats{}
+		s.stats[name] = st
+	}
+	return st
+}
+
+func (s *LoadAwareSecondarySelector) Select(candidateNames []string) (string, bool) {
+	if len(candidateNames) == 0 {
+		return "", false
+	}
+	if len(candidateNames) == 1 {
+		return candidateNames[0], true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, name := range candidateNames {
+		if s.statsFor(name).samples == 0 {
+			// At least one candidate is unobserved: fall back to the
+			// expression's own ordering rather than guessing.
+			return candidateNames[0], true
+		}
+	}
+
+	// Power-of-two-choices: sample two distinct candidates and take
+	// whichever currently scores lower, to avoid herding every request
+	// onto whichever single secondary looked best a moment ago.
+	firstIdx, secondIdx := pickTwoDistinctIndices(s.rand, len(candidateNames))
+	first := candidateNames[firstIdx]
+	second := candidateNames[secondIdx]
+
+	if s.score(first) <= s.score(second) {
+		return first, true
+	}
+	return second, true
+}
+
+// pickTwoDistinctIndices draws two distinct indices in [0, n). Drawing the
+// second from the n-1 indices that remain after the first (rather than
+// from the full range again) is what guarantees the two are distinct;
+// two independent draws over the full range collide about 1/n of the
+// time, which would silently degrade power-of-two-choices into a plain
+// random pick whenever they did. n must be at least 2.
+func pickTwoDistinctIndices(rnd *rand.Rand, n int) (int, int) {
+	first := rnd.Intn(n)
+	second := rnd.Intn(n - 1)
+	if second >= first {
+		second++
+	}
+	return first, second
+}
+
+func (s *LoadAwareSecondarySelector) score(name string) float64 {
+	st := s.statsFor(name)
+	return float64(st.ewmaLatency) * (1 + float64(st.inFlight))
+}
+
+// ewmaAlpha weights the most recent latency sample; 0.2 gives roughly the
+// last 5 samples meaningful influence over the running average.
+const ewmaAlpha = 0.2
+
+func (s *LoadAwareSecondarySelector) Observe(name string, latency time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st := s.statsFor(name)
+	if st.samples == 0 {
+		st.ewmaLatency = latency
+	} else {
+		st.ewmaLatency = time.Duration(ewmaAlpha*float64(latency) + (1-ewmaAlpha)*float64(st.ewmaLatency))
+	}
+	st.samples++
+}
+
+func (s *LoadAwareSecondarySelector) BeginRequest(name string) func(latency time.Duration) {
+	s.mu.Lock()
+	s.statsFor(name).inFlight++
+	s.mu.Unlock()
+
+	var once sync.Once
+	return func(latency time.Duration) {
+		once.Do(func() {
+			s.mu.Lock()
+			s.statsFor(name).inFlight--
+			s.mu.Unlock()
+
+			s.Observe(name, latency)
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package remote
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadAwareSecondarySelectorFallsBackWhenUnobserved(t *testing.T) {
+	require := require.New(t)
+
+	selector := NewLoadAwareSecondarySelector()
+
+	name, ok := selector.Select([]string{"secondary", "tertiary"})
+	require.True(ok)
+	require.Equal("secondary", name, "expression's first candidate should win until both have samples")
+}
+
+func TestLoadAwareSecondarySelectorSingleCandidate(t *testing.T) {
+	require := require.New(t)
+
+	selector := NewLoadAwareSecondarySelector()
+	name, ok := selector.Select([]string{"only"})
+	require.True(ok)
+	require.Equal("only", name)
+
+	_, ok = selector.Select(nil)
+	require.False(ok)
+}
+
+// TestLoadAwareSecondarySelectorPrefersFasterBackend simulates skewed
+// latencies across a fast and a slow secondary (mirroring
+// fakeDispatchSvc.sleepTime in cluster_test.go) and asserts that, once both
+// have been observed, the faster backend wins the large majority of
+// selections.
+func TestLoadAwareSecondarySelectorPrefersFasterBackend(t *testing.T) {
+	require := require.New(t)
+
+	selector := NewLoadAwareSecondarySelector()
+	selector.Observe("fast", 5*time.Millisecond)
+	selector.Observe("slow", 100*time.Millisecond)
+
+	const iterations = 1000
+	fastWins := 0
+	for i := 0; i < iterations; i++ {
+		name, ok := selector.Select([]string{"fast", "slow"})
+		require.True(ok)
+		if name == "fast" {
+			fastWins++
+		}
+	}
+
+	require.Greater(fastWins, iterations*3/4, "fast backend should win the large majority of power-of-two-choices selections")
+}
+
+// TestPickTwoDistinctIndicesNeverCollide guards against the
+// power-of-two-choices draw picking the same candidate twice, which would
+// silently collapse it into a plain random pick.
+func TestPickTwoDistinctIndicesNeverCollide(t *testing.T) {
+	require := require.New(t)
+
+	rnd := rand.New(rand.NewSource(1))
+	for n := 2; n <= 5; n++ {
+		for i := 0; i < 1000; i++ {
+			first, second := pickTwoDistinctIndices(rnd, n)
+			require.NotEqual(first, second)
+			require.Less(first, n)
+			require.Less(second, n)
+		}
+	}
+}
+
+func TestLoadAwareSecondarySelectorTracksInFlight(t *testing.T) {
+	require := require.New(t)
+
+	selector := NewLoadAwareSecondarySelector()
+	selector.Observe("secondary", 10*time.Millisecond)
+	selector.Observe("tertiary", 10*time.Millisecond)
+
+	// Pile up in-flight requests against "secondary" so its score rises
+	// even though its observed latency is identical to "tertiary".
+	for i := 0; i < 10; i++ {
+		selector.BeginRequest("secondary")
+	}
+
+	const iterations = 1000
+	tertiaryWins := 0
+	for i := 0; i < iterations; i++ {
+		name, ok := selector.Select([]string{"secondary", "tertiary"})
+		require.True(ok)
+		if name == "tertiary" {
+			tertiaryWins++
+		}
+	}
+
+	require.Greater(tertiaryWins, iterations*3/4, "less-loaded backend should win the large majority of selections")
+}
@@ -0,0 +1,17 @@
+// Package namespace defines the interface used to validate namespace and
+// relation references against the currently-written schema.
+package namespace
+
+import "context"
+
+// Manager validates namespace and relation references against the
+// schema currently on record, so callers like Watch can reject requests
+// for namespaces or relations that don't exist instead of silently
+// returning nothing for them.
+type Manager interface {
+	// CheckNamespaceAndRelation returns an error if namespace does not
+	// exist, or if relation does not exist on namespace. When allowEllipsis
+	// is true, relation may additionally be datastore.Ellipsis, which is
+	// always considered valid.
+	CheckNamespaceAndRelation(ctx context.Context, namespace, relation string, allowEllipsis bool) error
+}
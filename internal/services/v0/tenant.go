@@ -0,0 +1,80 @@
+package v0
+
+import (
+	"context"
+	"crypto/x509"
+	"strings"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// tenantMetadataKey is the gRPC metadata header used by callers that are not
+// authenticating via an mTLS SPIFFE identity to declare which tenant they are
+// operating as.
+const tenantMetadataKey = "spicedb-tenant"
+
+// tenantContextKey is the context.Context key under which the caller's
+// resolved tenant is stored.
+type tenantContextKey struct{}
+
+// ContextWithTenant returns a new context with the given tenant attached.
+func ContextWithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, tenant)
+}
+
+// TenantFromContext returns the tenant previously attached to ctx via
+// ContextWithTenant, if any.
+func TenantFromContext(ctx context.Context) (string, bool) {
+	tenant, ok := ctx.Value(tenantContextKey{}).(string)
+	return tenant, ok
+}
+
+// tenantFromRequest resolves the calling tenant from the incoming gRPC
+// metadata header, falling back to the SPIFFE ID presented by the peer's
+// mTLS certificate, if any.
+func tenantFromRequest(ctx context.Context) (string, bool) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(tenantMetadataKey); len(values) > 0 && values[0] != "" {
+			return values[0], true
+		}
+	}
+
+	return tenantFromSPIFFEID(ctx)
+}
+
+// tenantFromSPIFFEID extracts a tenant from the SPIFFE ID (spiffe://<trust
+// domain>/ns/<tenant>/...) presented in the peer's mTLS certificate, if the
+// connection was authenticated that way.
+func tenantFromSPIFFEID(ctx context.Context) (string, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.AuthInfo == nil {
+		return "", false
+	}
+
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.PeerCertificates) == 0 {
+		return "", false
+	}
+
+	cert := tlsInfo.State.PeerCertificates[0]
+	return tenantFromCertificate(cert)
+}
+
+func tenantFromCertificate(cert *x509.Certificate) (string, bool) {
+	for _, uri := range cert.URIs {
+		if uri.Scheme != "spiffe" {
+			continue
+		}
+
+		parts := strings.Split(strings.Trim(uri.Path, "/"), "/")
+		for i, part := range parts {
+			if part == "ns" && i+1 < len(parts) {
+				return parts[i+1], true
+			}
+		}
+	}
+
+	return "", false
+}
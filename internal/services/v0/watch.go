@@ -16,13 +16,19 @@ import (
 type watchServer struct {
 	v0.UnimplementedWatchServiceServer
 
-	ds  datastore.Datastore
-	nsm namespace.Manager
+	ds      datastore.Datastore
+	nsm     namespace.Manager
+	cursors WatchCursorStore
+	zookies *zookie.Signer
 }
 
-// NewWatchServer creates an instance of the watch server.
-func NewWatchServer(ds datastore.Datastore, nsm namespace.Manager) v0.WatchServiceServer {
-	s := &watchServer{ds: ds}
+// NewWatchServer creates an instance of the watch server. signingKey must
+// be a deployment-wide secret shared by every watchServer in the
+// deployment; it is used to HMAC-tag every zookie this server mints, so
+// that a StartRevision's embedded tenant cannot be forged by a client that
+// decodes a zookie, edits the tenant, and re-encodes it.
+func NewWatchServer(ds datastore.Datastore, nsm namespace.Manager, cursors WatchCursorStore, signingKey []byte) v0.WatchServiceServer {
+	s := &watchServer{ds: ds, nsm: nsm, cursors: cursors, zookies: zookie.NewSigner(signingKey)}
 	return s
 }
 
@@ -32,9 +38,21 @@ func (ws *watchServer) Watch(req *v0.WatchRequest, stream v0.WatchService_WatchS
 		return status.Errorf(codes.InvalidArgument, "invalid argument: %s", err)
 	}
 
+	callerTenant, hasCallerTenant := tenantFromRequest(stream.Context())
+
+	// Attach the resolved tenant to ctx so that every downstream call this
+	// handler makes - CheckNamespaceAndRelation here, and eventually a
+	// tenant-aware Datastore implementation's write/query path - can read
+	// it back via TenantFromContext instead of the caller needing to
+	// re-resolve it from the gRPC metadata or mTLS peer certificate.
+	ctx := stream.Context()
+	if hasCallerTenant {
+		ctx = ContextWithTenant(ctx, callerTenant)
+	}
+
 	namespaceMap := make(map[string]struct{})
 	for _, ns := range req.Namespaces {
-		err = ws.nsm.CheckNamespaceAndRelation(stream.Context(), ns, datastore.Ellipsis, true)
+		err = ws.nsm.CheckNamespaceAndRelation(ctx, ns, datastore.Ellipsis, true)
 		if err != nil {
 			return status.Errorf(codes.FailedPrecondition, "invalid namespace: %s", err)
 		}
@@ -43,35 +61,86 @@ func (ws *watchServer) Watch(req *v0.WatchRequest, stream v0.WatchService_WatchS
 	}
 	filter := namespaceFilter{namespaces: namespaceMap}
 
+	tenantMap := make(map[string]struct{}, len(req.TenantIds))
+	for _, tenantID := range req.TenantIds {
+		if hasCallerTenant && tenantID != callerTenant {
+			return status.Errorf(codes.PermissionDenied, "cannot watch tenant %q as tenant %q", tenantID, callerTenant)
+		}
+		tenantMap[tenantID] = struct{}{}
+	}
+	if len(tenantMap) == 0 && hasCallerTenant {
+		tenantMap[callerTenant] = struct{}{}
+	}
+	tenantFilterInst := tenantFilter{tenants: tenantMap}
+
 	var afterRevision decimal.Decimal
 	if req.StartRevision != nil && req.StartRevision.Token != "" {
-		decodedRevision, err := zookie.DecodeRevision(req.StartRevision)
+		// Signed, so a client cannot hijack another tenant's resume point
+		// by decoding a zookie, editing its tenant field, and re-encoding
+		// it: ws.zookies.DecodeTenant below rejects any token whose tag
+		// doesn't verify against this deployment's signing key.
+		decodedRevision, err := ws.zookies.DecodeRevision(req.StartRevision)
 		if err != nil {
 			status.Errorf(codes.InvalidArgument, "failed to decode start revision: %s", err)
 		}
 
+		if hasCallerTenant {
+			zookieTenant, err := ws.zookies.DecodeTenant(req.StartRevision)
+			if err != nil {
+				return status.Errorf(codes.InvalidArgument, "failed to decode start revision tenant: %s", err)
+			}
+			if zookieTenant != "" && zookieTenant != callerTenant {
+				return status.Errorf(codes.PermissionDenied, "start revision belongs to a different tenant")
+			}
+		}
+
 		afterRevision = decodedRevision
 	} else {
 		var err error
-		afterRevision, err = ws.ds.Revision(stream.Context())
+		afterRevision, err = ws.ds.Revision(ctx)
 		if err != nil {
 			status.Errorf(codes.Unavailable, "failed to start watch: %s", err)
 		}
 	}
 
-	updates, errchan := ws.ds.Watch(stream.Context(), afterRevision)
+	// If the caller supplied a cursor_id, prefer its persisted revision
+	// over the caller's own StartRevision whenever it is newer: the server
+	// is the source of truth for "how far has this consumer actually
+	// gotten", since the client may have crashed before persisting its own
+	// last-seen revision.
+	cursorID := req.CursorId
+	if cursorID != "" && ws.cursors != nil {
+		cursorTenant, _, lastAcked, found, err := ws.cursors.Get(ctx, cursorID)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to load watch cursor: %s", err)
+		}
+		if found && cursorTenant != callerTenant {
+			return status.Errorf(codes.PermissionDenied, "cursor %q belongs to a different tenant", cursorID)
+		}
+		if found && lastAcked.GreaterThan(afterRevision) {
+			afterRevision = lastAcked
+		}
+	}
+
+	updates, errchan := ws.ds.Watch(ctx, afterRevision, tenantFilterInst.tenantIDs())
 	for {
 		select {
 		case update, ok := <-updates:
 			if ok {
-				filtered := filter.filterUpdates(update.Changes)
+				filtered := filter.filterUpdates(tenantFilterInst.filterUpdates(update.Changes))
 				if len(filtered) > 0 {
 					if err := stream.Send(&v0.WatchResponse{
-						Updates:     update.Changes,
-						EndRevision: zookie.NewFromRevision(update.Revision),
+						Updates:     filtered,
+						EndRevision: ws.zookies.NewFromRevisionAndTenant(update.Revision, callerTenant),
 					}); err != nil {
 						return status.Errorf(codes.Canceled, "watch canceled by user: %s", err)
 					}
+
+					if cursorID != "" && ws.cursors != nil {
+						if err := ws.cursors.Persist(ctx, cursorID, callerTenant, req.Namespaces, update.Revision); err != nil {
+							return status.Errorf(codes.Internal, "failed to persist watch cursor: %s", err)
+						}
+					}
 				}
 			}
 		case err := <-errchan:
@@ -79,6 +148,15 @@ func (ws *watchServer) Watch(req *v0.WatchRequest, stream v0.WatchService_WatchS
 			case errors.As(err, &datastore.ErrWatchCanceled{}):
 				return status.Errorf(codes.Canceled, "watch canceled by user: %s", err)
 			case errors.As(err, &datastore.ErrWatchDisconnected{}):
+				// The requested start revision has fallen behind the
+				// changelog's GC horizon. If the caller supplied a
+				// cursor_id, report this as a typed CursorExpired detail so
+				// the client can tell "replay too old" apart from a
+				// generic disconnect and decide whether to restart from
+				// scratch.
+				if cursorID != "" {
+					return cursorExpiredStatus(cursorID)
+				}
 				return status.Errorf(codes.ResourceExhausted, "watch disconnected: %s", err)
 			default:
 				return status.Errorf(codes.Internal, "watch error: %s", err)
@@ -100,5 +178,35 @@ func (nf namespaceFilter) filterUpdates(candidates []*v0.RelationTupleUpdate) []
 		}
 	}
 
+	return filtered
+}
+
+// tenantFilter drops updates whose tuple was not written under one of the
+// requested tenants. An empty tenants set means "no tenant scoping
+// requested" and passes every update through unfiltered.
+type tenantFilter struct {
+	tenants map[string]struct{}
+}
+
+func (tf tenantFilter) tenantIDs() []string {
+	ids := make([]string, 0, len(tf.tenants))
+	for tenantID := range tf.tenants {
+		ids = append(ids, tenantID)
+	}
+	return ids
+}
+
+func (tf tenantFilter) filterUpdates(candidates []*v0.RelationTupleUpdate) []*v0.RelationTupleUpdate {
+	if len(tf.tenants) == 0 {
+		return candidates
+	}
+
+	var filtered []*v0.RelationTupleUpdate
+	for _, update := range candidates {
+		if _, ok := tf.tenants[update.Tuple.TenantId]; ok {
+			filtered = append(filtered, update)
+		}
+	}
+
 	return filtered
 }
\ No newline at end of file
@@ -0,0 +1,44 @@
+package watchsink
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// ParseSinks builds the WatchSink implementations named by the repeated
+// --watch-sink flag. Each spec is of the form "<kind>" or "<kind>:<arg>",
+// e.g. "null", "kv:/var/lib/spicedb/watch.db", or
+// "psql:postgres://host/db?sslmode=disable".
+func ParseSinks(ctx context.Context, specs []string) ([]WatchSink, error) {
+	sinks := make([]WatchSink, 0, len(specs))
+	for _, spec := range specs {
+		kind, arg, _ := strings.Cut(spec, ":")
+		switch kind {
+		case "null":
+			sinks = append(sinks, NewNullSink())
+		case "kv":
+			if arg == "" {
+				return nil, fmt.Errorf("watch sink %q requires a file path argument", spec)
+			}
+			sink, err := NewKVSink(arg)
+			if err != nil {
+				return nil, err
+			}
+			sinks = append(sinks, sink)
+		case "psql":
+			if arg == "" {
+				return nil, fmt.Errorf("watch sink %q requires a postgres connection string argument", spec)
+			}
+			sink, err := NewPSQLSink(ctx, arg, 0)
+			if err != nil {
+				return nil, err
+			}
+			sinks = append(sinks, sink)
+		default:
+			return nil, fmt.Errorf("unknown watch sink kind %q", kind)
+		}
+	}
+
+	return sinks, nil
+}
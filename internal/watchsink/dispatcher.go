@@ -0,0 +1,99 @@
+package watchsink
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/authzed/spicedb/internal/datastore"
+)
+
+// Dispatcher owns a single datastore.Datastore.Watch call and fans each
+// update out to every configured WatchSink, so that sinks do not each open
+// their own Watch stream against the datastore.
+type Dispatcher struct {
+	ds    datastore.Datastore
+	sinks []WatchSink
+}
+
+// NewDispatcher creates a Dispatcher that will fan out updates to sinks.
+func NewDispatcher(ds datastore.Datastore, sinks ...WatchSink) *Dispatcher {
+	return &Dispatcher{ds: ds, sinks: sinks}
+}
+
+// Run starts the underlying Watch and blocks, publishing every update to
+// every sink, until ctx is canceled or the datastore reports a terminal
+// watch error. It is intended to be run in its own goroutine for the
+// lifetime of the server.
+func (d *Dispatcher) Run(ctx context.Context, afterRevision decimal.Decimal) error {
+	// Resume from no later than the furthest-behind sink actually needs:
+	// a sink that persisted its own high-water mark below afterRevision
+	// (e.g. because it came up after the others on a prior run) would
+	// otherwise silently miss every change in between. Re-delivering
+	// changes a sink already has is safe, since every sink's Publish is a
+	// revision-keyed upsert.
+	resumeFrom := afterRevision
+	for _, sink := range d.sinks {
+		lastPersisted, found, err := sink.LastPersistedRevision(ctx)
+		if err != nil {
+			return err
+		}
+		if found && lastPersisted.LessThan(resumeFrom) {
+			resumeFrom = lastPersisted
+		}
+	}
+
+	for _, sink := range d.sinks {
+		if err := sink.Start(ctx, resumeFrom); err != nil {
+			return err
+		}
+	}
+	defer func() {
+		for _, sink := range d.sinks {
+			if err := sink.Close(); err != nil {
+				slog.ErrorContext(ctx, "failed to close watch sink", "error", err)
+			}
+		}
+	}()
+
+	// No tenant scoping: the dispatcher fans every sink out from a single
+	// shared Watch stream, and per-sink tenant filtering (if any) happens
+	// in the sink itself, same as namespaceFilter/tenantFilter do for the
+	// gRPC-facing watchServer.
+	updates, errchan := d.ds.Watch(ctx, resumeFrom, nil)
+	for {
+		select {
+		case update, ok := <-updates:
+			if !ok {
+				// A closed channel never blocks, so looping here would
+				// busy-spin this select forever instead of idling until
+				// errchan or ctx.Done() actually has something to say.
+				// The datastore always closes updates alongside sending a
+				// final error (or ctx.Done() firing), so returning nil
+				// and letting the next iteration's errchan/ctx.Done()
+				// case handle the real reason is unreachable in
+				// practice, but block rather than spin if it ever isn't.
+				updates = nil
+				continue
+			}
+
+			for _, sink := range d.sinks {
+				if err := sink.Publish(ctx, WatchUpdate{Changes: update.Changes, EndRevision: update.Revision}); err != nil {
+					slog.ErrorContext(ctx, "watch sink failed to publish update", "error", err)
+				}
+			}
+		case err := <-errchan:
+			if err == nil {
+				continue
+			}
+			if errors.As(err, &datastore.ErrWatchCanceled{}) {
+				return nil
+			}
+			return err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
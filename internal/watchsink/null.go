@@ -0,0 +1,27 @@
+package watchsink
+
+import (
+	"context"
+
+	"github.com/shopspring/decimal"
+)
+
+// NullSink discards every update it receives. It exists so operators can
+// benchmark the cost of the fan-out path itself without paying for any
+// downstream storage.
+type NullSink struct{}
+
+// NewNullSink creates a WatchSink that drops every update it is given.
+func NewNullSink() *NullSink {
+	return &NullSink{}
+}
+
+func (*NullSink) Start(_ context.Context, _ decimal.Decimal) error { return nil }
+
+func (*NullSink) Publish(_ context.Context, _ WatchUpdate) error { return nil }
+
+func (*NullSink) LastPersistedRevision(_ context.Context) (decimal.Decimal, bool, error) {
+	return decimal.Decimal{}, false, nil
+}
+
+func (*NullSink) Close() error { return nil }
@@ -0,0 +1,44 @@
+// Package watchsink provides pluggable destinations for the change feed
+// produced by datastore.Datastore.Watch, so that systems other than the
+// gRPC WatchService (audit logs, cache invalidators, analytics pipelines)
+// can tap the feed without each opening their own Watch stream.
+package watchsink
+
+import (
+	"context"
+
+	"github.com/shopspring/decimal"
+
+	v0 "github.com/authzed/spicedb/pkg/proto/authzed/api/v0"
+)
+
+// WatchUpdate is a single batch of tuple changes committed at EndRevision,
+// mirroring the shape streamed by datastore.Datastore.Watch.
+type WatchUpdate struct {
+	Changes     []*v0.RelationTupleUpdate
+	EndRevision decimal.Decimal
+}
+
+// WatchSink is a destination that a watchsink.Dispatcher fans change events
+// out to, alongside the gRPC WatchService stream. Implementations must be
+// safe to call from a single goroutine in the order Start, Publish*, Close.
+type WatchSink interface {
+	// Start prepares the sink to receive updates whose revision is after
+	// afterRevision. Sinks that persist their own high-water mark (e.g. kv,
+	// psql) may use this to resume rather than replay from the beginning.
+	Start(ctx context.Context, afterRevision decimal.Decimal) error
+
+	// Publish delivers a single watch update to the sink. It must not
+	// retain references to update's slices beyond the call.
+	Publish(ctx context.Context, update WatchUpdate) error
+
+	// LastPersistedRevision returns the most recent revision this sink has
+	// durably persisted, if any, so a Dispatcher resuming after a restart
+	// can start the shared Watch stream no later than the furthest-behind
+	// sink actually needs, instead of trusting a single external
+	// afterRevision that may already be ahead of what a given sink wrote.
+	LastPersistedRevision(ctx context.Context) (decimal.Decimal, bool, error)
+
+	// Close releases any resources held by the sink.
+	Close() error
+}
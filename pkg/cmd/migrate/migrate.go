@@ -0,0 +1,152 @@
+// Package migrate implements the standalone `spicedb migrate` subcommand,
+// which runs the postgres datastore migrations out-of-band from server
+// boot: `up`, `down`, `status`, `redo`, and `create`.
+package migrate
+
+import (
+	"database/sql"
+	"fmt"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/pressly/goose/v3"
+	"github.com/spf13/cobra"
+
+	"github.com/authzed/spicedb/internal/datastore/postgres/migrations"
+)
+
+// NewCommand creates the `spicedb migrate` command and its subcommands.
+func NewCommand() *cobra.Command {
+	var datastoreURI string
+
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "runs datastore migrations out-of-band from server startup",
+	}
+	cmd.PersistentFlags().StringVar(&datastoreURI, "datastore-conn-uri", "", "connection string used to connect to the datastore")
+
+	cmd.AddCommand(
+		newUpCommand(&datastoreURI),
+		newDownCommand(&datastoreURI),
+		newStatusCommand(&datastoreURI),
+		newRedoCommand(&datastoreURI),
+		newCreateCommand(),
+	)
+
+	return cmd
+}
+
+func openProvider(datastoreURI string) (*sql.DB, *migrations.Provider, error) {
+	db, err := sql.Open("pgx", datastoreURI)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open datastore connection: %w", err)
+	}
+
+	provider, err := migrations.NewProvider(db)
+	if err != nil {
+		_ = db.Close()
+		return nil, nil, err
+	}
+
+	return db, provider, nil
+}
+
+func newUpCommand(datastoreURI *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "up",
+		Short: "applies every migration that has not yet been run",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			db, provider, err := openProvider(*datastoreURI)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			return provider.Up(cmd.Context())
+		},
+	}
+}
+
+func newDownCommand(datastoreURI *string) *cobra.Command {
+	var all bool
+
+	cmd := &cobra.Command{
+		Use:   "down",
+		Short: "rolls back the most recently applied migration",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			db, provider, err := openProvider(*datastoreURI)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			if all {
+				return provider.DownToZero(cmd.Context())
+			}
+			return provider.Down(cmd.Context())
+		},
+	}
+	cmd.Flags().BoolVar(&all, "all", false, "roll back every applied migration instead of just the most recent one")
+
+	return cmd
+}
+
+func newRedoCommand(datastoreURI *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "redo",
+		Short: "rolls back and then re-applies the most recently applied migration",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			db, provider, err := openProvider(*datastoreURI)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			return provider.Redo(cmd.Context())
+		},
+	}
+}
+
+func newStatusCommand(datastoreURI *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "reports which migrations have been applied",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			db, provider, err := openProvider(*datastoreURI)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			statuses, err := provider.Status(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			for _, s := range statuses {
+				state := "pending"
+				if s.State == goose.StateApplied {
+					state = "applied"
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "%s\t%s\n", s.Source.Path, state)
+			}
+			return nil
+		},
+	}
+}
+
+func newCreateCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "create [name]",
+		Short: "scaffolds a new numbered migration file",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return goose.CreateWithTemplate(
+				nil,
+				"internal/datastore/postgres/migrations",
+				nil,
+				args[0],
+				"sql",
+			)
+		},
+	}
+}
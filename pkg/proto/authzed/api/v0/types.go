@@ -0,0 +1,102 @@
+// Package v0 contains the Go types for authzed/api/v0/v0.proto.
+package v0
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type Zookie struct {
+	Token string
+}
+
+type ObjectAndRelation struct {
+	Namespace string
+	ObjectId  string
+	Relation  string
+}
+
+type RelationTuple struct {
+	ObjectAndRelation *ObjectAndRelation
+	User              *ObjectAndRelation
+	TenantId          string
+}
+
+type RelationTupleUpdate struct {
+	Operation int32
+	Tuple     *RelationTuple
+}
+
+type WatchRequest struct {
+	Namespaces    []string
+	StartRevision *Zookie
+	TenantIds     []string
+	CursorId      string
+}
+
+// Validate reports whether req is well-formed enough to serve; it does not
+// check namespace existence or tenant authorization, which are the
+// caller's responsibility.
+func (req *WatchRequest) Validate() error {
+	for _, tenantID := range req.TenantIds {
+		if tenantID == "" {
+			return status.Error(codes.InvalidArgument, "tenant_ids must not contain an empty tenant")
+		}
+	}
+	return nil
+}
+
+type WatchResponse struct {
+	Updates     []*RelationTupleUpdate
+	EndRevision *Zookie
+}
+
+type WatchAckRequest struct {
+	CursorId   string
+	Revision   *Zookie
+	Namespaces []string
+}
+
+type WatchAckResponse struct{}
+
+type WatchCursorDeleteRequest struct {
+	CursorId string
+}
+
+type WatchCursorDeleteResponse struct{}
+
+// WatchService_WatchServer is the server-side stream for the Watch RPC.
+type WatchService_WatchServer interface {
+	grpc.ServerStream
+	Send(*WatchResponse) error
+}
+
+// WatchServiceServer is the server API for WatchService.
+type WatchServiceServer interface {
+	Watch(*WatchRequest, WatchService_WatchServer) error
+	WatchAck(context.Context, *WatchAckRequest) (*WatchAckResponse, error)
+	WatchCursorDelete(context.Context, *WatchCursorDeleteRequest) (*WatchCursorDeleteResponse, error)
+	mustEmbedUnimplementedWatchServiceServer()
+}
+
+// UnimplementedWatchServiceServer must be embedded by implementations of
+// WatchServiceServer to satisfy forward compatibility as new RPCs are
+// added to the service.
+type UnimplementedWatchServiceServer struct{}
+
+func (UnimplementedWatchServiceServer) Watch(*WatchRequest, WatchService_WatchServer) error {
+	return status.Error(codes.Unimplemented, "method Watch not implemented")
+}
+
+func (UnimplementedWatchServiceServer) WatchAck(context.Context, *WatchAckRequest) (*WatchAckResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method WatchAck not implemented")
+}
+
+func (UnimplementedWatchServiceServer) WatchCursorDelete(context.Context, *WatchCursorDeleteRequest) (*WatchCursorDeleteResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method WatchCursorDelete not implemented")
+}
+
+func (UnimplementedWatchServiceServer) mustEmbedUnimplementedWatchServiceServer() {}
@@ -0,0 +1,151 @@
+// Package v1 contains the Go types for dispatch/v1/v1.proto: the subset of
+// the cross-node dispatch API needed by internal/dispatch/remote and
+// internal/dispatch/graph in this tree.
+package v1
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type ResolvedResource_Permissionship int32
+
+const (
+	ResolvedResource_UNKNOWN ResolvedResource_Permissionship = iota
+	ResolvedResource_HAS_PERMISSION
+	ResolvedResource_CONDITIONALLY_HAS_PERMISSION
+)
+
+type ResolvedResource struct {
+	ResourceId         string
+	Permissionship     ResolvedResource_Permissionship
+	ExcludedSubjectIds []string
+}
+
+type Cursor struct {
+	Sections        []string
+	DispatchVersion uint32
+}
+
+type SchemaChangedEvent struct {
+	Namespace string
+	Revision  string
+}
+
+type WatchSchemaChangesRequest struct{}
+
+const dispatchServiceName = "dispatch.v1.DispatchService"
+
+// DispatchService_WatchSchemaChangesServer is the server-side stream for
+// the WatchSchemaChanges RPC.
+type DispatchService_WatchSchemaChangesServer interface {
+	grpc.ServerStream
+	Send(*SchemaChangedEvent) error
+}
+
+// DispatchService_WatchSchemaChangesClient is the client-side stream for
+// the WatchSchemaChanges RPC.
+type DispatchService_WatchSchemaChangesClient interface {
+	grpc.ClientStream
+	Recv() (*SchemaChangedEvent, error)
+}
+
+// DispatchServiceServer is the server API for DispatchService. Only
+// WatchSchemaChanges is modeled here; this tree has no production
+// implementation of the rest of the real DispatchService (DispatchCheck,
+// DispatchExpand, DispatchLookupResources2, ...), so those are
+// intentionally omitted rather than stubbed out dishonestly.
+type DispatchServiceServer interface {
+	WatchSchemaChanges(*WatchSchemaChangesRequest, DispatchService_WatchSchemaChangesServer) error
+	mustEmbedUnimplementedDispatchServiceServer()
+}
+
+// UnimplementedDispatchServiceServer must be embedded by implementations
+// of DispatchServiceServer to satisfy forward compatibility.
+type UnimplementedDispatchServiceServer struct{}
+
+func (UnimplementedDispatchServiceServer) WatchSchemaChanges(*WatchSchemaChangesRequest, DispatchService_WatchSchemaChangesServer) error {
+	return status.Error(codes.Unimplemented, "method WatchSchemaChanges not implemented")
+}
+
+func (UnimplementedDispatchServiceServer) mustEmbedUnimplementedDispatchServiceServer() {}
+
+// RegisterDispatchServiceServer registers srv against s, the same way a
+// protoc-gen-go-grpc-generated RegisterDispatchServiceServer would.
+func RegisterDispatchServiceServer(s grpc.ServiceRegistrar, srv DispatchServiceServer) {
+	s.RegisterService(&dispatchServiceDesc, srv)
+}
+
+// DispatchServiceClient is the client API for DispatchService.
+type DispatchServiceClient interface {
+	WatchSchemaChanges(ctx context.Context, in *WatchSchemaChangesRequest, opts ...grpc.CallOption) (DispatchService_WatchSchemaChangesClient, error)
+}
+
+type dispatchServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewDispatchServiceClient creates a DispatchServiceClient backed by cc.
+func NewDispatchServiceClient(cc grpc.ClientConnInterface) DispatchServiceClient {
+	return &dispatchServiceClient{cc}
+}
+
+func (c *dispatchServiceClient) WatchSchemaChanges(ctx context.Context, in *WatchSchemaChangesRequest, opts ...grpc.CallOption) (DispatchService_WatchSchemaChangesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &dispatchServiceDesc.Streams[0], "/"+dispatchServiceName+"/WatchSchemaChanges", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &dispatchServiceWatchSchemaChangesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type dispatchServiceWatchSchemaChangesClient struct {
+	grpc.ClientStream
+}
+
+func (x *dispatchServiceWatchSchemaChangesClient) Recv() (*SchemaChangedEvent, error) {
+	m := new(SchemaChangedEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func dispatchServiceWatchSchemaChangesHandler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchSchemaChangesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DispatchServiceServer).WatchSchemaChanges(m, &dispatchServiceWatchSchemaChangesServer{stream})
+}
+
+type dispatchServiceWatchSchemaChangesServer struct {
+	grpc.ServerStream
+}
+
+func (x *dispatchServiceWatchSchemaChangesServer) Send(m *SchemaChangedEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var dispatchServiceDesc = grpc.ServiceDesc{
+	ServiceName: dispatchServiceName,
+	HandlerType: (*DispatchServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchSchemaChanges",
+			Handler:       dispatchServiceWatchSchemaChangesHandler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "dispatch/v1/v1.proto",
+}